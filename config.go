@@ -0,0 +1,292 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	configDirName    = "quickstream"
+	configFileName   = "config.toml"
+	legacyConfigFile = ".quickstream.json"
+)
+
+// configChangedMsg is sent whenever the config file changes on disk, either
+// because the user edited it externally (fsnotify) or exited $EDITOR (the
+// "e" command).
+type configChangedMsg struct{}
+
+// Destination is one simulcast target: where to send the stream and what
+// platform it belongs to. Platform is stored in the config but not otherwise
+// used by the UI; key redaction is the same for every platform.
+type Destination struct {
+	Name     string `toml:"name" json:"name"`
+	URL      string `toml:"url" json:"url"`
+	Key      string `toml:"key" json:"key"`
+	Platform string `toml:"platform" json:"platform"`
+	Enabled  bool   `toml:"enabled" json:"enabled"`
+}
+
+// Preset is a named ffmpeg encoding configuration, either structured (so
+// buildArgs can template in hardware acceleration) or a raw argument string
+// for anything the structured fields don't cover.
+type Preset struct {
+	Name       string `toml:"name" json:"name"`
+	Input      string `toml:"input" json:"input"`
+	VideoCodec string `toml:"video_codec" json:"video_codec"`
+	AudioCodec string `toml:"audio_codec" json:"audio_codec"`
+	Bitrate    string `toml:"bitrate" json:"bitrate"`
+	Resolution string `toml:"resolution" json:"resolution"`
+	Framerate  string `toml:"framerate" json:"framerate"`
+	UseHWAccel bool   `toml:"use_hwaccel" json:"use_hwaccel"`
+	RawArgs    string `toml:"raw_args" json:"raw_args"`
+}
+
+// Config is the full contents of config.toml.
+type Config struct {
+	Destinations []Destination `toml:"destinations" json:"destinations"`
+	Presets      []Preset      `toml:"presets" json:"presets"`
+}
+
+// configFilePath returns ~/.config/quickstream/config.toml (or the
+// platform equivalent via os.UserConfigDir).
+func configFilePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, configDirName, configFileName), nil
+}
+
+// legacyConfigFilePath returns the pre-chunk0-4 ~/.quickstream.json location.
+func legacyConfigFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, legacyConfigFile), nil
+}
+
+// loadConfig reads config.toml, migrating from the legacy JSON file on first
+// run. Any failure just falls back to an empty Config so the TUI always
+// starts with something to add to.
+func loadConfig() Config {
+	path, err := configFilePath()
+	if err != nil {
+		return Config{}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if config, ok := migrateLegacyConfig(); ok {
+			_ = saveConfig(config)
+			return config
+		}
+		return Config{}
+	}
+
+	var config Config
+	if err := toml.Unmarshal(data, &config); err != nil {
+		return Config{}
+	}
+	return config
+}
+
+// saveConfig writes config.toml, creating ~/.config/quickstream if needed.
+func saveConfig(config Config) error {
+	path, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return toml.NewEncoder(f).Encode(config)
+}
+
+// legacyConfig is the pre-chunk0-4 on-disk shape: parallel URL/enabled
+// arrays and either structured or raw-string presets.
+type legacyConfig struct {
+	URLs        []string        `json:"urls"`
+	URLEnabled  []bool          `json:"url_enabled"`
+	Presets     json.RawMessage `json:"presets"`
+	SelectedURL int             `json:"selected_url"`
+}
+
+// migrateLegacyConfig reads ~/.quickstream.json, if present, and converts it
+// to the current Destination/Preset shape. Presets may be the chunk0-3
+// struct form or the original raw-string form; both are tried.
+func migrateLegacyConfig() (Config, bool) {
+	path, err := legacyConfigFilePath()
+	if err != nil {
+		return Config{}, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, false
+	}
+
+	var legacy legacyConfig
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return Config{}, false
+	}
+
+	var config Config
+	for i, rawURL := range legacy.URLs {
+		enabled := i < len(legacy.URLEnabled) && legacy.URLEnabled[i]
+		base, key := splitStreamKey(rawURL)
+		config.Destinations = append(config.Destinations, Destination{
+			Name:     base,
+			URL:      base,
+			Key:      key,
+			Platform: inferPlatform(rawURL),
+			Enabled:  enabled,
+		})
+	}
+
+	if len(legacy.Presets) > 0 {
+		var presets []Preset
+		if err := json.Unmarshal(legacy.Presets, &presets); err == nil {
+			config.Presets = presets
+		} else {
+			var rawPresets []string
+			if err := json.Unmarshal(legacy.Presets, &rawPresets); err == nil {
+				for i, raw := range rawPresets {
+					config.Presets = append(config.Presets, Preset{
+						Name:    fmt.Sprintf("Custom %d", i+1),
+						RawArgs: raw,
+					})
+				}
+			}
+		}
+	}
+
+	return config, true
+}
+
+// splitStreamKey peels the credential portion off a destination URL so it
+// can be stored and redacted separately from the base URL: the last path
+// segment for RTMP/RTSP-style URLs (the stream key), or the query string for
+// SRT, which carries streamid/passphrase there instead of in the path. The
+// returned base retains its trailing "/" or "?" so revealedURL/maskedURL can
+// just concatenate. If the URL has neither a path nor a query after the
+// scheme, there's no credential to split out.
+func splitStreamKey(raw string) (base, key string) {
+	schemeIdx := strings.Index(raw, "://")
+	if schemeIdx < 0 {
+		return raw, ""
+	}
+	scheme := raw[:schemeIdx]
+
+	if strings.EqualFold(scheme, "srt") {
+		if qIdx := strings.Index(raw, "?"); qIdx >= 0 && qIdx < len(raw)-1 {
+			return raw[:qIdx+1], raw[qIdx+1:]
+		}
+		return raw, ""
+	}
+
+	if !strings.Contains(raw[schemeIdx+3:], "/") {
+		return raw, ""
+	}
+
+	idx := strings.LastIndex(raw, "/")
+	if idx < 0 || idx == len(raw)-1 {
+		return raw, ""
+	}
+	return raw[:idx+1], raw[idx+1:]
+}
+
+// revealedURL rejoins a destination's base URL and stream key into the full
+// URL ffmpeg needs to actually stream to.
+func revealedURL(dest Destination) string {
+	if dest.Key == "" {
+		return dest.URL
+	}
+	return dest.URL + dest.Key
+}
+
+// maskedURL renders a destination's URL with its stream key replaced by
+// bullets, e.g. "rtmp://example.com/live/••••", so it's safe to put on
+// screen in screenshots and screen shares.
+func maskedURL(dest Destination) string {
+	if dest.Key == "" {
+		return dest.URL
+	}
+	return dest.URL + "••••"
+}
+
+// inferPlatform guesses a destination's platform from its URL: "twitch",
+// "youtube", or "custom" for anything else.
+func inferPlatform(url string) string {
+	lower := strings.ToLower(url)
+	switch {
+	case strings.Contains(lower, "twitch"):
+		return "twitch"
+	case strings.Contains(lower, "youtube"):
+		return "youtube"
+	default:
+		return "custom"
+	}
+}
+
+// startConfigWatcher watches the config file for external changes (e.g. the
+// user editing it directly, or a sync tool replacing it) and signals ch on
+// every write. Failures to start the watcher are silently ignored: hot-reload
+// is a convenience, not a requirement to run the TUI.
+func startConfigWatcher(path string, ch chan<- struct{}) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(path) {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					ch <- struct{}{}
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// waitForConfigChange turns the next signal on ch into a configChangedMsg.
+func waitForConfigChange(ch <-chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		if ch == nil {
+			return nil
+		}
+		<-ch
+		return configChangedMsg{}
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const defaultVAAPIDevice = "/dev/dri/renderD128"
+
+// HWAccel records which hardware encoders this machine's ffmpeg offers: an
+// encoder counts when ffmpeg lists both its hwaccel method and its encoder,
+// and VAAPI additionally needs its render node to exist. Whether the GPU and
+// driver actually work is not checked.
+type HWAccel struct {
+	NVENC        bool
+	VAAPI        bool
+	VAAPIDevice  string
+	QSV          bool
+	VideoToolbox bool
+}
+
+// detectHWAccel probes `ffmpeg -hwaccels` and `-encoders` at startup. Probing
+// is best-effort: if ffmpeg isn't on PATH, detection just comes back empty
+// and templates fall back to software encoding.
+func detectHWAccel() HWAccel {
+	hwaccels := ffmpegProbeOutput("-hide_banner", "-hwaccels")
+	encoders := ffmpegProbeOutput("-hide_banner", "-encoders")
+
+	var hw HWAccel
+	hw.NVENC = strings.Contains(hwaccels, "cuda") && strings.Contains(encoders, "h264_nvenc")
+	hw.QSV = strings.Contains(hwaccels, "qsv") && strings.Contains(encoders, "h264_qsv")
+	hw.VideoToolbox = strings.Contains(hwaccels, "videotoolbox") && strings.Contains(encoders, "h264_videotoolbox")
+
+	if strings.Contains(hwaccels, "vaapi") && strings.Contains(encoders, "h264_vaapi") {
+		if _, err := os.Stat(defaultVAAPIDevice); err == nil {
+			hw.VAAPI = true
+			hw.VAAPIDevice = defaultVAAPIDevice
+		}
+	}
+
+	return hw
+}
+
+func ffmpegProbeOutput(args ...string) string {
+	out, err := exec.Command("ffmpeg", args...).CombinedOutput()
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+// hwaccelInitArgs returns the ffmpeg init flags a hardware codec needs before
+// the rest of its args, e.g. selecting the VAAPI render node or the CUDA
+// hwaccel output format.
+func hwaccelInitArgs(videoCodec string, hw HWAccel) []string {
+	switch videoCodec {
+	case "h264_nvenc":
+		return []string{"-hwaccel", "cuda", "-hwaccel_output_format", "cuda"}
+	case "h264_vaapi":
+		device := hw.VAAPIDevice
+		if device == "" {
+			device = defaultVAAPIDevice
+		}
+		return []string{"-vaapi_device", device, "-hwaccel", "vaapi", "-hwaccel_output_format", "vaapi"}
+	case "h264_qsv":
+		return []string{"-hwaccel", "qsv"}
+	case "h264_videotoolbox":
+		return []string{"-hwaccel", "videotoolbox"}
+	default:
+		return nil
+	}
+}
@@ -1,52 +1,191 @@
 package main
 
 import (
-	"encoding/json"
+	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
-	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
 )
 
-const configFile = ".quickstream.json"
-
 var (
 	titleStyle        = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#0455DD")).MarginBottom(1)
 	selectedItemStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F50404")).Bold(true)
 	helpStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("#AAAAAA")).MarginTop(1)
 	urlBoxStyle       = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("#04D575")).Padding(1).MarginBottom(1)
 	presetBoxStyle    = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("#D50404")).Padding(1)
+	telemetryBoxStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("#04AAD5")).Padding(1).MarginTop(1)
 	selectedURLStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#04F575")).Bold(true)
 	normalURLStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#AAAAAA"))
+	reconnectStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#F5A504")).Bold(true)
+	paletteMatchStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F50404")).Bold(true)
 )
 
-type Config struct {
-	URLs    []string `json:"urls"`
-	Presets []string `json:"string"`
+var sparkChars = []rune("▁▂▃▄▅▆▇█")
+
+const bitrateHistoryLen = 30
+
+// buildArgs templates the preset into ffmpeg arguments, prepending the init
+// flags for the preset's own VideoCodec when UseHWAccel is set (with the
+// detected VAAPI render node, if any). The codec is not replaced by a
+// detected encoder, and whether that encoder was detected is not checked.
+func (p Preset) buildArgs(hw HWAccel) []string {
+	if p.RawArgs != "" {
+		return strings.Fields(p.RawArgs)
+	}
+
+	var args []string
+	if p.UseHWAccel {
+		args = append(args, hwaccelInitArgs(p.VideoCodec, hw)...)
+	}
+	args = append(args, strings.Fields(p.Input)...)
+	if p.Resolution != "" {
+		args = append(args, "-s", p.Resolution)
+	}
+	if p.Framerate != "" {
+		args = append(args, "-r", p.Framerate)
+	}
+	if p.VideoCodec != "" {
+		args = append(args, "-c:v", p.VideoCodec)
+	}
+	if p.Bitrate != "" {
+		args = append(args, "-b:v", p.Bitrate)
+	}
+	if p.AudioCodec != "" {
+		args = append(args, "-c:a", p.AudioCodec)
+	}
+	return args
+}
+
+// builtinTemplates lists the presets shown in the Templates picker, limited
+// to the hardware encoders detectHWAccel found on this machine.
+func builtinTemplates(hw HWAccel) []Preset {
+	const capture = "-f v4l2 -i /dev/video0 -f alsa -i plughw:2,0"
+
+	templates := []Preset{
+		{Name: "720p30 software x264", Input: capture, VideoCodec: "libx264", AudioCodec: "aac", Bitrate: "3M", Resolution: "1280x720", Framerate: "30"},
+	}
+	if hw.NVENC {
+		templates = append(templates, Preset{Name: "1080p60 NVENC", Input: capture, VideoCodec: "h264_nvenc", AudioCodec: "aac", Bitrate: "6M", Resolution: "1920x1080", Framerate: "60", UseHWAccel: true})
+	}
+	if hw.VAAPI {
+		templates = append(templates, Preset{Name: "VAAPI 1080p", Input: capture, VideoCodec: "h264_vaapi", AudioCodec: "aac", Bitrate: "6M", Resolution: "1920x1080", Framerate: "30", UseHWAccel: true})
+	}
+	if hw.QSV {
+		templates = append(templates, Preset{Name: "QSV 1080p", Input: capture, VideoCodec: "h264_qsv", AudioCodec: "aac", Bitrate: "6M", Resolution: "1920x1080", Framerate: "30", UseHWAccel: true})
+	}
+	if hw.VideoToolbox {
+		templates = append(templates, Preset{Name: "VideoToolbox 1080p", Input: capture, VideoCodec: "h264_videotoolbox", AudioCodec: "aac", Bitrate: "6M", Resolution: "1920x1080", Framerate: "30", UseHWAccel: true})
+	}
+	return templates
+}
+
+// destStatus tracks the outcome of streaming to a single destination URL.
+// ffmpeg's tee muxer only reports combined output size (see telemetryView),
+// not a per-slave byte count, so this intentionally stops at State.
+type destStatus struct {
+	URL   string
+	State string // "started" or "failed"
+}
+
+// destErrorMsg reports that ffmpeg's stderr logged a failure tied to a
+// specific simulcast destination, identified by its index into the
+// destStatuses/urls slices built for this stream.
+type destErrorMsg struct {
+	index int
+}
+
+// progressMsg is one `-progress pipe:1` block from ffmpeg, decoded from its
+// `key=value` lines and delivered to Update as a tea.Msg.
+type progressMsg struct {
+	Frame       int
+	FPS         float64
+	Bitrate     string
+	BitrateKbps float64
+	TotalSize   int64
+	OutTimeMs   int64
+	DropFrames  int
+	DupFrames   int
+	Speed       string
+	Done        bool // true when ffmpeg reported progress=end
+}
+
+// streamEndedMsg is sent once ffmpeg's progress pipe closes.
+type streamEndedMsg struct{}
+
+// validationResultMsg carries the outcome of the pre-flight checks run by
+// runValidation, which dials every enabled destination and so must not
+// block the UI loop.
+type validationResultMsg struct {
+	issues []validationIssue
+}
+
+// runValidation runs collectValidationIssues as a tea.Cmd so the reachability
+// dials it performs don't freeze the UI while Enter is held down.
+func runValidation(config Config, presetIndex int) tea.Cmd {
+	return func() tea.Msg {
+		return validationResultMsg{issues: collectValidationIssues(config, presetIndex)}
+	}
 }
 
 type model struct {
-	config         Config
-	urlIndex       int // Selected URL index
-	presetIndex    int // Selected preset index
-	selectedURL    int // Confirmed selected URL (-1 if not selected)
-	selectedPreset int // Confirmed selected preset (-1 if not selected)
-	showAddURL     bool
-	showAddPreset  bool
-	urlInput       textinput.Model
-	presetInput    textinput.Model
-	streaming      bool
-	streamCmd      *exec.Cmd
+	config           Config
+	urlIndex         int // Selected URL index
+	presetIndex      int // Selected preset index
+	selectedPreset   int // Confirmed selected preset (-1 if not selected)
+	showAddURL       bool
+	showAddPreset    bool
+	urlInput         textinput.Model
+	presetInput      textinput.Model
+	streaming        bool
+	streamCmd        *exec.Cmd
+	destStatuses     []destStatus
+	progressCh       chan progressMsg
+	stderrCh         chan destErrorMsg
+	lastProgress     progressMsg
+	bitrateHistory   []float64
+	reconnecting     bool
+	streamEnded      bool
+	hwaccel          HWAccel
+	showTemplates    bool
+	templateIndex    int
+	configCh         chan struct{}
+	showPalette      bool
+	paletteInput     textinput.Model
+	paletteIndex     int
+	revealKey        bool
+	validating       bool
+	showValidation   bool
+	validationIssues []validationIssue
 }
 
 func (m model) Init() tea.Cmd {
-	return nil
+	return waitForConfigChange(m.configCh)
+}
+
+// reloadConfig re-reads the config file from disk, clamping the current
+// selection indices if destinations or presets were removed externally.
+func (m model) reloadConfig() model {
+	m.config = loadConfig()
+	if m.urlIndex >= len(m.config.Destinations) {
+		m.urlIndex = len(m.config.Destinations) - 1
+	}
+	if m.presetIndex >= len(m.config.Presets) {
+		m.presetIndex = len(m.config.Presets) - 1
+	}
+	if m.selectedPreset >= len(m.config.Presets) {
+		m.selectedPreset = -1
+	}
+	return m
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -56,6 +195,43 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		return m, nil
 
+	case progressMsg:
+		m.lastProgress = msg
+		m.bitrateHistory = append(m.bitrateHistory, msg.BitrateKbps)
+		if len(m.bitrateHistory) > bitrateHistoryLen {
+			m.bitrateHistory = m.bitrateHistory[len(m.bitrateHistory)-bitrateHistoryLen:]
+		}
+		// ffmpeg reports progress=end both on a clean stop and right before it
+		// reconnects to a flaky destination; only the latter happens while we're
+		// still meant to be streaming.
+		m.reconnecting = msg.Done && m.streaming
+		return m, waitForProgress(m.progressCh)
+
+	case destErrorMsg:
+		if msg.index >= 0 && msg.index < len(m.destStatuses) {
+			m.destStatuses[msg.index].State = "failed"
+		}
+		return m, waitForDestError(m.stderrCh)
+
+	case streamEndedMsg:
+		m.streaming = false
+		m.reconnecting = false
+		m.streamEnded = true
+		return m, nil
+
+	case configChangedMsg:
+		m = m.reloadConfig()
+		return m, waitForConfigChange(m.configCh)
+
+	case validationResultMsg:
+		m.validating = false
+		if len(msg.issues) > 0 {
+			m.validationIssues = msg.issues
+			m.showValidation = true
+			return m, nil
+		}
+		return m.startStreaming()
+
 	case tea.KeyMsg:
 		// Handle form inputs
 		if m.showAddURL {
@@ -64,6 +240,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.showAddPreset {
 			return m.updateAddPreset(msg)
 		}
+		if m.showTemplates {
+			return m.updateTemplates(msg)
+		}
+		if m.showPalette {
+			return m.updatePalette(msg)
+		}
+		if m.showValidation {
+			return m.updateValidation(msg)
+		}
 
 		switch msg.String() {
 		case "ctrl+c", "q":
@@ -74,24 +259,29 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "w", "W":
 			// Move up in URL list
-			if len(m.config.URLs) > 0 {
+			if len(m.config.Destinations) > 0 {
 				m.urlIndex--
 				if m.urlIndex < 0 {
-					m.urlIndex = len(m.config.URLs) - 1
+					m.urlIndex = len(m.config.Destinations) - 1
 				}
-				m.selectedURL = -1 // Reset selection when moving
 			}
+			m.revealKey = false
 			return m, nil
 
 		case "s", "S":
 			// Move down in URL list
-			if len(m.config.URLs) > 0 {
+			if len(m.config.Destinations) > 0 {
 				m.urlIndex++
-				if m.urlIndex >= len(m.config.URLs) {
+				if m.urlIndex >= len(m.config.Destinations) {
 					m.urlIndex = 0
 				}
-				m.selectedURL = -1 // Reset selection when moving
 			}
+			m.revealKey = false
+			return m, nil
+
+		case "v":
+			// Reveal/hide the stream key of the URL under the cursor
+			m.revealKey = !m.revealKey
 			return m, nil
 
 		case "up":
@@ -116,33 +306,48 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case " ":
+			// Toggle the URL under the cursor in/out of the simulcast set
+			if len(m.config.Destinations) > 0 && m.urlIndex >= 0 && m.urlIndex < len(m.config.Destinations) {
+				m.config.Destinations[m.urlIndex].Enabled = !m.config.Destinations[m.urlIndex].Enabled
+				_ = saveConfig(m.config)
+			}
+			return m, nil
+
 		case "enter":
-			// Select URL and/or start streaming
-			if len(m.config.URLs) > 0 && m.urlIndex >= 0 && m.urlIndex < len(m.config.URLs) {
-				m.selectedURL = m.urlIndex
+			if m.validating {
+				return m, nil
+			}
+			// Commit the URL selection and/or start streaming
+			if len(m.config.Destinations) > 0 && m.urlIndex >= 0 && m.urlIndex < len(m.config.Destinations) && !anyEnabled(m.config.Destinations) {
+				m.config.Destinations[m.urlIndex].Enabled = true
+				_ = saveConfig(m.config)
 			}
 			if len(m.config.Presets) > 0 && m.presetIndex >= 0 && m.presetIndex < len(m.config.Presets) {
 				m.selectedPreset = m.presetIndex
 			}
-			// If both selected, start streaming
-			if m.selectedURL >= 0 && m.selectedPreset >= 0 {
-				return m.startStreaming()
+			// If at least one URL and a preset are selected, run pre-flight
+			// checks before spawning ffmpeg against a doomed destination/preset.
+			// The checks dial every enabled destination, so they run as a
+			// tea.Cmd instead of blocking the UI loop.
+			if anyEnabled(m.config.Destinations) && m.selectedPreset >= 0 {
+				m.validating = true
+				return m, runValidation(m.config, m.selectedPreset)
 			}
 			return m, nil
 
 		case "shift+a", "shift+A", "A":
 			// Delete URL (Shift+A or capital A)
-			if len(m.config.URLs) > 0 && m.urlIndex >= 0 && m.urlIndex < len(m.config.URLs) {
-				m.config.URLs = append(m.config.URLs[:m.urlIndex], m.config.URLs[m.urlIndex+1:]...)
+			if len(m.config.Destinations) > 0 && m.urlIndex >= 0 && m.urlIndex < len(m.config.Destinations) {
+				m.config.Destinations = append(m.config.Destinations[:m.urlIndex], m.config.Destinations[m.urlIndex+1:]...)
 				_ = saveConfig(m.config)
 				// Adjust urlIndex if needed
-				if m.urlIndex >= len(m.config.URLs) {
-					m.urlIndex = len(m.config.URLs) - 1
+				if m.urlIndex >= len(m.config.Destinations) {
+					m.urlIndex = len(m.config.Destinations) - 1
 				}
-				if m.urlIndex < 0 && len(m.config.URLs) > 0 {
+				if m.urlIndex < 0 && len(m.config.Destinations) > 0 {
 					m.urlIndex = 0
 				}
-				m.selectedURL = -1
 			}
 			return m, nil
 
@@ -181,6 +386,43 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.presetInput.CharLimit = 1000
 			m.presetInput.Width = 500
 			return m, nil
+
+		case "t":
+			// Open the built-in hardware/software template picker
+			m.showTemplates = true
+			m.templateIndex = 0
+			return m, nil
+
+		case "/":
+			// Open the fuzzy-search palette over URLs and presets
+			m.showPalette = true
+			m.paletteIndex = 0
+			m.paletteInput = textinput.New()
+			m.paletteInput.Placeholder = "filter urls and presets…"
+			m.paletteInput.Focus()
+			m.paletteInput.CharLimit = 200
+			m.paletteInput.Width = 100
+			return m, nil
+
+		case "r":
+			// Manually reload the config file
+			m = m.reloadConfig()
+			return m, nil
+
+		case "e":
+			// Open $EDITOR on the config file, reloading once it exits
+			path, err := configFilePath()
+			if err != nil {
+				return m, nil
+			}
+			editor := os.Getenv("EDITOR")
+			if editor == "" {
+				editor = "vi"
+			}
+			editCmd := exec.Command(editor, path)
+			return m, tea.ExecProcess(editCmd, func(error) tea.Msg {
+				return configChangedMsg{}
+			})
 		}
 	}
 
@@ -192,11 +434,17 @@ func (m model) updateAddURL(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "enter":
-			url := strings.TrimSpace(m.urlInput.Value())
-			if url != "" {
-				m.config.URLs = append(m.config.URLs, url)
+			raw := strings.TrimSpace(m.urlInput.Value())
+			if raw != "" {
+				base, key := splitStreamKey(raw)
+				m.config.Destinations = append(m.config.Destinations, Destination{
+					Name:     base,
+					URL:      base,
+					Key:      key,
+					Platform: inferPlatform(raw),
+				})
 				_ = saveConfig(m.config)
-				m.urlIndex = len(m.config.URLs) - 1
+				m.urlIndex = len(m.config.Destinations) - 1
 				m.showAddURL = false
 			}
 			return m, nil
@@ -216,14 +464,15 @@ func (m model) updateAddPreset(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "enter":
-			preset := strings.TrimSpace(m.presetInput.Value())
-			if preset != "" {
-				m.config.Presets = append(m.config.Presets, preset)
-				_ = saveConfig(m.config)
-				m.presetIndex = len(m.config.Presets) - 1
-				m.showAddPreset = false
-			} else if len(m.config.Presets) == 0 {
-				m.config.Presets = append(m.config.Presets, "-f v4l2 -framerate 25 -video_size 1920x1080 -i /dev/video0 -f alsa -i plughw:2,0 libx264 aac -preset veryfast -maxrate 1M -bufsize 2M -pix_fmt yuv420p -b:a 96k -ar 44100")
+			raw := strings.TrimSpace(m.presetInput.Value())
+			if raw == "" && len(m.config.Presets) == 0 {
+				raw = "-f v4l2 -framerate 25 -video_size 1920x1080 -i /dev/video0 -f alsa -i plughw:2,0 libx264 aac -preset veryfast -maxrate 1M -bufsize 2M -pix_fmt yuv420p -b:a 96k -ar 44100"
+			}
+			if raw != "" {
+				m.config.Presets = append(m.config.Presets, Preset{
+					Name:    fmt.Sprintf("Custom %d", len(m.config.Presets)+1),
+					RawArgs: raw,
+				})
 				_ = saveConfig(m.config)
 				m.presetIndex = len(m.config.Presets) - 1
 				m.showAddPreset = false
@@ -240,28 +489,307 @@ func (m model) updateAddPreset(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m model) updateTemplates(msg tea.Msg) (tea.Model, tea.Cmd) {
+	templates := builtinTemplates(m.hwaccel)
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up":
+			if len(templates) > 0 {
+				m.templateIndex--
+				if m.templateIndex < 0 {
+					m.templateIndex = len(templates) - 1
+				}
+			}
+			return m, nil
+		case "down":
+			if len(templates) > 0 {
+				m.templateIndex++
+				if m.templateIndex >= len(templates) {
+					m.templateIndex = 0
+				}
+			}
+			return m, nil
+		case "enter":
+			if m.templateIndex >= 0 && m.templateIndex < len(templates) {
+				tmpl := templates[m.templateIndex]
+				if i := presetIndexByName(m.config.Presets, tmpl.Name); i >= 0 {
+					m.presetIndex = i
+				} else {
+					m.config.Presets = append(m.config.Presets, tmpl)
+					m.presetIndex = len(m.config.Presets) - 1
+				}
+				_ = saveConfig(m.config)
+			}
+			m.showTemplates = false
+			return m, nil
+		case "esc":
+			m.showTemplates = false
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// updateValidation handles the modal shown when collectValidationIssues
+// finds a problem: enter streams anyway, esc cancels.
+func (m model) updateValidation(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			m.showValidation = false
+			m.validationIssues = nil
+			return m.startStreaming()
+		case "esc":
+			m.showValidation = false
+			m.validationIssues = nil
+			m.selectedPreset = -1
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+// validationView renders the pre-flight check modal.
+func (m model) validationView() string {
+	var b strings.Builder
+	b.WriteString("Pre-flight checks found issues\n")
+	for _, issue := range m.validationIssues {
+		b.WriteString(fmt.Sprintf("  [%s] %s\n", issue.Severity, issue.Message))
+	}
+
+	return fmt.Sprintf(
+		"\n%s\n\n%s\n\n%s",
+		titleStyle.Render("Validation"),
+		presetBoxStyle.Render(b.String()),
+		helpStyle.Render("enter: start anyway • esc: cancel"),
+	)
+}
+
+// paletteItem is one entry in the fuzzy-search palette: either a destination
+// or a preset, tagged with its index into the corresponding config slice.
+type paletteItem struct {
+	kind  string // "url" or "preset"
+	label string
+	index int
+}
+
+// paletteItems lists every destination and preset as palette entries, in the
+// order fuzzy.Find expects to rank them against.
+func paletteItems(config Config) []paletteItem {
+	items := make([]paletteItem, 0, len(config.Destinations)+len(config.Presets))
+	for i, d := range config.Destinations {
+		items = append(items, paletteItem{kind: "url", label: d.Name, index: i})
+	}
+	for i, p := range config.Presets {
+		items = append(items, paletteItem{kind: "preset", label: p.Name, index: i})
+	}
+	return items
+}
+
+// filteredPaletteMatches ranks paletteItems against the current palette
+// input, or returns them all unranked (no matched runes) when the input is
+// empty.
+func filteredPaletteMatches(items []paletteItem, query string) fuzzy.Matches {
+	if query == "" {
+		matches := make(fuzzy.Matches, len(items))
+		for i := range items {
+			matches[i] = fuzzy.Match{Str: items[i].label, Index: i}
+		}
+		return matches
+	}
+
+	labels := make([]string, len(items))
+	for i, it := range items {
+		labels[i] = it.label
+	}
+	return fuzzy.Find(query, labels)
+}
+
+func (m model) updatePalette(msg tea.Msg) (tea.Model, tea.Cmd) {
+	items := paletteItems(m.config)
+	matches := filteredPaletteMatches(items, m.paletteInput.Value())
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up":
+			if len(matches) > 0 {
+				m.paletteIndex--
+				if m.paletteIndex < 0 {
+					m.paletteIndex = len(matches) - 1
+				}
+			}
+			return m, nil
+		case "down":
+			if len(matches) > 0 {
+				m.paletteIndex++
+				if m.paletteIndex >= len(matches) {
+					m.paletteIndex = 0
+				}
+			}
+			return m, nil
+		case "enter":
+			if m.paletteIndex >= 0 && m.paletteIndex < len(matches) {
+				item := items[matches[m.paletteIndex].Index]
+				switch item.kind {
+				case "url":
+					m.urlIndex = item.index
+					m.config.Destinations[item.index].Enabled = true
+					_ = saveConfig(m.config)
+				case "preset":
+					m.presetIndex = item.index
+					m.selectedPreset = item.index
+				}
+			}
+			m.showPalette = false
+			return m, nil
+		case "esc":
+			m.showPalette = false
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.paletteInput, cmd = m.paletteInput.Update(msg)
+	m.paletteIndex = 0
+	return m, cmd
+}
+
+// highlightMatches renders label with its fuzzy-matched rune positions bolded.
+func highlightMatches(label string, matched []int) string {
+	if len(matched) == 0 {
+		return label
+	}
+	set := make(map[int]bool, len(matched))
+	for _, idx := range matched {
+		set[idx] = true
+	}
+
+	var b strings.Builder
+	for i, r := range []rune(label) {
+		if set[i] {
+			b.WriteString(paletteMatchStyle.Render(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// paletteView renders the fuzzy-search palette shown when the user presses "/".
+func (m model) paletteView() string {
+	items := paletteItems(m.config)
+	matches := filteredPaletteMatches(items, m.paletteInput.Value())
+
+	var b strings.Builder
+	if len(matches) == 0 {
+		b.WriteString("-No matches-\n")
+	} else {
+		for i, match := range matches {
+			item := items[match.Index]
+			line := fmt.Sprintf("[%s] %s", item.kind, highlightMatches(item.label, match.MatchedIndexes))
+			if i == m.paletteIndex {
+				line = "► " + line
+			} else {
+				line = "  " + line
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+
+	return fmt.Sprintf(
+		"\n%s\n\n%s\n\n%s\n\n%s",
+		titleStyle.Render("Search"),
+		m.paletteInput.View(),
+		presetBoxStyle.Render(b.String()),
+		helpStyle.Render("↑/↓: select • enter: choose • esc: cancel"),
+	)
+}
+
+// presetIndexByName returns the index of the preset with the given name, or
+// -1 if it hasn't been added to the config yet.
+func presetIndexByName(presets []Preset, name string) int {
+	for i, p := range presets {
+		if p.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
 func (m model) startStreaming() (tea.Model, tea.Cmd) {
+	if m.selectedPreset < 0 || m.selectedPreset >= len(m.config.Presets) {
+		// The preset was removed (e.g. an external config edit) between
+		// selection and this call; there's nothing to stream.
+		m.selectedPreset = -1
+		return m, nil
+	}
+
 	if m.streamCmd != nil && m.streamCmd.Process != nil {
 		_ = m.streamCmd.Process.Kill() // force kill
 		_ = m.streamCmd.Wait()         // reap the process
 		m.streamCmd = nil
 	}
 
-	url := m.config.URLs[m.selectedURL]
+	urls := enabledURLs(m.config)
+	maskedURLs := maskedEnabledURLs(m.config)
 	preset := m.config.Presets[m.selectedPreset]
-	presetArgs := strings.Fields(preset)
-	// Build ffmpeg command
+	presetArgs := preset.buildArgs(m.hwaccel)
+
+	// Build ffmpeg command. A single destination is a plain flv output; multiple
+	// destinations share one capture via the tee muxer so we only decode/encode once.
 	args := []string{}
 	args = append(args, presetArgs...)
-	args = append(args, "-f", "flv", url)
+	args = append(args, "-progress", "pipe:1", "-nostats")
+	if len(urls) == 1 {
+		args = append(args, "-f", "flv", urls[0])
+	} else {
+		teeOutputs := make([]string, len(urls))
+		for i, u := range urls {
+			teeOutputs[i] = fmt.Sprintf("[f=flv]%s", u)
+		}
+		args = append(args, "-f", "tee", strings.Join(teeOutputs, "|"))
+	}
+
+	m.destStatuses = make([]destStatus, len(maskedURLs))
+	for i, u := range maskedURLs {
+		m.destStatuses[i] = destStatus{URL: u, State: "started"}
+	}
 
 	m.streaming = true
-	m.selectedURL = -1
 	m.selectedPreset = -1
+	m.lastProgress = progressMsg{}
+	m.bitrateHistory = nil
+	m.reconnecting = false
+	m.streamEnded = false
 
 	cmd := exec.Command("ffmpeg", args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+
+	// ffmpeg's stdout/stderr are never written to the terminal directly: stdout
+	// carries the `-progress` key=value stream parsed by readProgress, and stderr
+	// carries its log output, scanned by readStderr for per-destination tee
+	// muxer failures. Writing either straight through would corrupt the
+	// Bubbletea alt-screen.
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		for i := range m.destStatuses {
+			m.destStatuses[i].State = "failed"
+		}
+		return m, nil
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		for i := range m.destStatuses {
+			m.destStatuses[i].State = "failed"
+		}
+		return m, nil
+	}
 
 	// Detach process
 	cmd.SysProcAttr = &syscall.SysProcAttr{
@@ -270,13 +798,192 @@ func (m model) startStreaming() (tea.Model, tea.Cmd) {
 
 	// Start in background
 	if err := cmd.Start(); err != nil {
-		// handle error
+		for i := range m.destStatuses {
+			m.destStatuses[i].State = "failed"
+		}
 		return m, nil
 	}
 
 	m.streamCmd = cmd
+	m.progressCh = make(chan progressMsg)
+	go readProgress(stdout, m.progressCh)
 
-	return m, nil
+	m.stderrCh = make(chan destErrorMsg)
+	go readStderr(stderr, urls, m.stderrCh)
+
+	return m, tea.Batch(waitForProgress(m.progressCh), waitForDestError(m.stderrCh))
+}
+
+// readProgress scans ffmpeg's `-progress pipe:1` stream, which emits a run of
+// `key=value` lines terminated by `progress=continue` or `progress=end`, and
+// sends one decoded progressMsg per block until the pipe closes.
+func readProgress(stdout io.ReadCloser, ch chan<- progressMsg) {
+	defer close(ch)
+
+	scanner := bufio.NewScanner(stdout)
+	var cur progressMsg
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch key {
+		case "frame":
+			cur.Frame, _ = strconv.Atoi(value)
+		case "fps":
+			cur.FPS, _ = strconv.ParseFloat(value, 64)
+		case "bitrate":
+			cur.Bitrate = value
+			cur.BitrateKbps = parseBitrateKbps(value)
+		case "total_size":
+			cur.TotalSize, _ = strconv.ParseInt(value, 10, 64)
+		case "out_time_ms":
+			cur.OutTimeMs, _ = strconv.ParseInt(value, 10, 64)
+		case "drop_frames":
+			cur.DropFrames, _ = strconv.Atoi(value)
+		case "dup_frames":
+			cur.DupFrames, _ = strconv.Atoi(value)
+		case "speed":
+			cur.Speed = value
+		case "progress":
+			cur.Done = value == "end"
+			ch <- cur
+			cur = progressMsg{}
+		}
+	}
+}
+
+// parseBitrateKbps parses ffmpeg's "1234.5kbits/s" progress field, returning 0
+// for the "N/A" it reports before the first measurement.
+func parseBitrateKbps(value string) float64 {
+	kbps, _ := strconv.ParseFloat(strings.TrimSuffix(value, "kbits/s"), 64)
+	return kbps
+}
+
+// waitForProgress turns the next value on the progress channel into a
+// tea.Msg, or a streamEndedMsg once ffmpeg closes it.
+func waitForProgress(ch chan progressMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return streamEndedMsg{}
+		}
+		return msg
+	}
+}
+
+// readStderr scans ffmpeg's stderr for log lines mentioning one of the
+// simulcast destination URLs alongside "error"/"failed", which is how the
+// tee muxer reports a rejected stream key or a dropped connection to one
+// specific leg, and sends the matching destination's index.
+func readStderr(stderr io.ReadCloser, urls []string, ch chan<- destErrorMsg) {
+	defer close(ch)
+
+	scanner := bufio.NewScanner(stderr)
+	for scanner.Scan() {
+		line := scanner.Text()
+		lower := strings.ToLower(line)
+		if !strings.Contains(lower, "error") && !strings.Contains(lower, "failed") {
+			continue
+		}
+		for i, u := range urls {
+			if strings.Contains(line, u) {
+				ch <- destErrorMsg{index: i}
+				break
+			}
+		}
+	}
+}
+
+// waitForDestError turns the next value on the stderr-derived error channel
+// into a tea.Msg.
+func waitForDestError(ch chan destErrorMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// formatBytes renders a byte count in the largest binary unit that keeps it
+// >= 1, e.g. "4.2MiB", for compact display in the Encoder telemetry pane.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// sparkline renders samples as a compact bar-height string scaled between
+// their own min and max.
+func sparkline(samples []float64) string {
+	if len(samples) == 0 {
+		return ""
+	}
+	min, max := samples[0], samples[0]
+	for _, s := range samples {
+		if s < min {
+			min = s
+		}
+		if s > max {
+			max = s
+		}
+	}
+
+	var b strings.Builder
+	for _, s := range samples {
+		idx := len(sparkChars) - 1
+		if max > min {
+			idx = int((s - min) / (max - min) * float64(len(sparkChars)-1))
+		}
+		b.WriteRune(sparkChars[idx])
+	}
+	return b.String()
+}
+
+// anyEnabled reports whether at least one destination is toggled on.
+func anyEnabled(destinations []Destination) bool {
+	for _, d := range destinations {
+		if d.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// enabledURLs returns the full (key-included) URLs of destinations currently
+// toggled on, in list order.
+func enabledURLs(config Config) []string {
+	var urls []string
+	for _, d := range config.Destinations {
+		if d.Enabled {
+			urls = append(urls, revealedURL(d))
+		}
+	}
+	return urls
+}
+
+// maskedEnabledURLs returns the key-redacted URLs of destinations currently
+// toggled on, in the same order as enabledURLs, for display in the
+// Destinations status block while streaming.
+func maskedEnabledURLs(config Config) []string {
+	var urls []string
+	for _, d := range config.Destinations {
+		if d.Enabled {
+			urls = append(urls, maskedURL(d))
+		}
+	}
+	return urls
 }
 
 func (m model) View() string {
@@ -298,17 +1005,37 @@ func (m model) View() string {
 		)
 	}
 
+	if m.showTemplates {
+		return m.templatesView()
+	}
+
+	if m.showPalette {
+		return m.paletteView()
+	}
+
+	if m.showValidation {
+		return m.validationView()
+	}
+
 	// Render URL list
 	var urlView strings.Builder
 	urlView.WriteString("Stream URLs\n")
-	if len(m.config.URLs) == 0 {
+	if len(m.config.Destinations) == 0 {
 		urlView.WriteString("-No urls-\n")
 	} else {
-		for i, url := range m.config.URLs {
+		for i, dest := range m.config.Destinations {
+			checkbox := "[ ]"
+			if dest.Enabled {
+				checkbox = "[x]"
+			}
+			display := maskedURL(dest)
+			if i == m.urlIndex && m.revealKey {
+				display = revealedURL(dest)
+			}
 			if i == m.urlIndex {
-				urlView.WriteString(selectedURLStyle.Render(fmt.Sprintf("► %s", url)))
+				urlView.WriteString(selectedURLStyle.Render(fmt.Sprintf("► %s %s", checkbox, display)))
 			} else {
-				urlView.WriteString(normalURLStyle.Render(fmt.Sprintf("  %s", url)))
+				urlView.WriteString(normalURLStyle.Render(fmt.Sprintf("  %s %s", checkbox, display)))
 			}
 			urlView.WriteString("\n")
 		}
@@ -322,31 +1049,118 @@ func (m model) View() string {
 		presetView.WriteString("-No presets-\n")
 	} else {
 		for i, preset := range m.config.Presets {
+			line := fmt.Sprintf("%s (%s)", preset.Name, presetSummary(preset))
 			if i == m.presetIndex {
-				presetView.WriteString(selectedItemStyle.Render(fmt.Sprintf("► %s", preset)))
+				presetView.WriteString(selectedItemStyle.Render(fmt.Sprintf("► %s", line)))
 			} else {
-				presetView.WriteString(normalURLStyle.Render(fmt.Sprintf("  %s", preset)))
+				presetView.WriteString(normalURLStyle.Render(fmt.Sprintf("  %s", line)))
 			}
 			presetView.WriteString("\n")
 		}
 	}
 	presetBox := presetBoxStyle.Render(presetView.String())
 
-	helpText := helpStyle.Render("w/s: url • ↑/↓: preset • enter: start • a/p: add • shift+a/p: delete • q: quit")
+	var statusView strings.Builder
+	if m.validating {
+		statusView.WriteString(reconnectStyle.Render("validating destinations…"))
+		statusView.WriteString("\n")
+	}
+	if m.streamEnded {
+		statusView.WriteString(reconnectStyle.Render("stream ended"))
+		statusView.WriteString("\n")
+	}
+	if m.streaming && len(m.destStatuses) > 0 {
+		statusView.WriteString("Destinations\n")
+		for _, d := range m.destStatuses {
+			statusView.WriteString(fmt.Sprintf("  %s: %s\n", d.URL, d.State))
+		}
+	}
+	statusBox := statusView.String()
+
+	telemetryBox := ""
+	if m.streaming {
+		telemetryBox = telemetryBoxStyle.Render(m.telemetryView())
+	}
+
+	helpText := helpStyle.Render("w/s: url • ↑/↓: preset • space: toggle url • v: reveal key • enter: start • a/p: add • t: templates • /: search • e: edit config • r: reload config • shift+a/p: delete • q: quit")
 	return fmt.Sprintf(
-		"%s\n%s\n%s\n%s",
+		"%s\n%s\n%s\n%s%s\n%s",
 		titleStyle.Render("Quick Stream"),
 		urlBox,
 		presetBox,
+		statusBox,
+		telemetryBox,
 		helpText,
 	)
 }
 
+// presetSummary renders a one-line description of a preset for list display.
+func presetSummary(p Preset) string {
+	if p.RawArgs != "" {
+		return "custom"
+	}
+	hw := ""
+	if p.UseHWAccel {
+		hw = " hw"
+	}
+	return fmt.Sprintf("%s %s@%sfps%s", p.Resolution, p.VideoCodec, p.Framerate, hw)
+}
+
+// templatesView renders the built-in template picker shown when the user
+// presses "t".
+func (m model) templatesView() string {
+	templates := builtinTemplates(m.hwaccel)
+
+	var b strings.Builder
+	b.WriteString("Templates\n")
+	if len(templates) == 0 {
+		b.WriteString("-No templates-\n")
+	} else {
+		for i, tmpl := range templates {
+			line := fmt.Sprintf("%s (%s)", tmpl.Name, presetSummary(tmpl))
+			if i == m.templateIndex {
+				b.WriteString(selectedItemStyle.Render(fmt.Sprintf("► %s", line)))
+			} else {
+				b.WriteString(normalURLStyle.Render(fmt.Sprintf("  %s", line)))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	return fmt.Sprintf(
+		"\n%s\n\n%s\n\n%s",
+		titleStyle.Render("Templates"),
+		presetBoxStyle.Render(b.String()),
+		helpStyle.Render("↑/↓: select • enter: use • esc: cancel"),
+	)
+}
+
+// telemetryView renders the current encoder stats parsed from ffmpeg's
+// progress pipe: fps/bitrate/dropped frames/elapsed time and a bitrate sparkline.
+func (m model) telemetryView() string {
+	p := m.lastProgress
+	elapsed := time.Duration(p.OutTimeMs) * time.Microsecond
+
+	var b strings.Builder
+	b.WriteString("Encoder\n")
+	if m.reconnecting {
+		b.WriteString(reconnectStyle.Render("  reconnecting…"))
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "  fps: %.1f  bitrate: %s  speed: %s\n", p.FPS, p.Bitrate, p.Speed)
+	fmt.Fprintf(&b, "  dropped: %d  duplicated: %d  elapsed: %s\n", p.DropFrames, p.DupFrames, elapsed.Round(time.Second))
+	fmt.Fprintf(&b, "  sent: %s (combined across all destinations)\n", formatBytes(p.TotalSize))
+	if len(m.bitrateHistory) > 0 {
+		fmt.Fprintf(&b, "  bitrate: %s\n", sparkline(m.bitrateHistory))
+	}
+	return b.String()
+}
+
 func main() {
 	config := loadConfig()
 
 	urlIndex := 0
-	if len(config.URLs) == 0 {
+	if len(config.Destinations) == 0 {
 		urlIndex = -1
 	}
 
@@ -359,8 +1173,13 @@ func main() {
 		config:         config,
 		urlIndex:       urlIndex,
 		presetIndex:    presetIndex,
-		selectedURL:    -1,
 		selectedPreset: -1,
+		hwaccel:        detectHWAccel(),
+		configCh:       make(chan struct{}),
+	}
+
+	if path, err := configFilePath(); err == nil {
+		startConfigWatcher(path, m.configCh)
 	}
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
@@ -369,43 +1188,3 @@ func main() {
 		os.Exit(1)
 	}
 }
-
-func loadConfig() Config {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		homeDir = "."
-	}
-	configPath := filepath.Join(homeDir, configFile)
-
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		// Return empty config if file doesn't exist
-		return Config{
-			URLs:    []string{},
-			Presets: []string{},
-		}
-	}
-
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing config: %v\n", err)
-		return Config{}
-	}
-
-	return config
-}
-
-func saveConfig(config Config) error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		homeDir = "."
-	}
-	configPath := filepath.Join(homeDir, configFile)
-
-	data, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	return os.WriteFile(configPath, data, 0o644)
-}
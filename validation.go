@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const dialTimeout = 1500 * time.Millisecond
+
+var validSchemes = map[string]bool{
+	"rtmp":  true,
+	"rtmps": true,
+	"srt":   true,
+	"rtsp":  true,
+}
+
+// validationIssue is one problem found during pre-flight checks, shown to
+// the user in the validation modal before ffmpeg is ever spawned.
+type validationIssue struct {
+	Severity string // "error" or "warning"
+	Message  string
+}
+
+// collectValidationIssues runs pre-flight checks over every enabled
+// destination and the selected preset, so problems with a URL or preset are
+// shown in the validation modal before startStreaming is called.
+func collectValidationIssues(config Config, presetIndex int) []validationIssue {
+	var issues []validationIssue
+
+	for _, d := range config.Destinations {
+		if d.Enabled {
+			issues = append(issues, validateDestination(d)...)
+		}
+	}
+
+	if presetIndex >= 0 && presetIndex < len(config.Presets) {
+		issues = append(issues, validatePreset(config.Presets[presetIndex])...)
+	}
+
+	return issues
+}
+
+// validateDestination checks a destination's URL scheme and dials its host,
+// so an unsupported protocol or an unreachable endpoint surfaces before
+// ffmpeg starts rather than after it immediately exits.
+func validateDestination(dest Destination) []validationIssue {
+	var issues []validationIssue
+
+	full := revealedURL(dest)
+	parsed, err := url.Parse(full)
+	if err != nil {
+		return append(issues, validationIssue{"error", fmt.Sprintf("%s: %v", dest.Name, err)})
+	}
+
+	if !validSchemes[strings.ToLower(parsed.Scheme)] {
+		issues = append(issues, validationIssue{"error", fmt.Sprintf("%s: unsupported scheme %q", dest.Name, parsed.Scheme)})
+	}
+
+	host := parsed.Host
+	if host == "" {
+		return append(issues, validationIssue{"error", fmt.Sprintf("%s: missing host", dest.Name)})
+	}
+	if !strings.Contains(host, ":") {
+		host += defaultPortFor(parsed.Scheme)
+	}
+
+	conn, err := net.DialTimeout("tcp", host, dialTimeout)
+	if err != nil {
+		issues = append(issues, validationIssue{"warning", fmt.Sprintf("%s: host unreachable (%v)", dest.Name, err)})
+	} else {
+		conn.Close()
+	}
+
+	return issues
+}
+
+// defaultPortFor fills in the conventional port for a scheme when the URL
+// doesn't specify one, so the reachability dial has somewhere to connect.
+func defaultPortFor(scheme string) string {
+	switch strings.ToLower(scheme) {
+	case "rtmp":
+		return ":1935"
+	case "rtmps":
+		return ":443"
+	case "rtsp":
+		return ":554"
+	case "srt":
+		return ":9999"
+	default:
+		return ":1935"
+	}
+}
+
+// tokenizePresetArgs splits a raw preset argument string on whitespace like
+// strings.Fields, except quoted sections may contain spaces and an
+// unbalanced quote is reported as an error instead of silently producing a
+// malformed token.
+func tokenizePresetArgs(raw string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+	var quote rune
+	inToken := false
+
+	for _, r := range raw {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inToken = true
+		case unicode.IsSpace(r):
+			if inToken {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+				inToken = false
+			}
+		default:
+			cur.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unbalanced quote")
+	}
+	if inToken {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens, nil
+}
+
+// validatePreset tokenizes a preset's raw args, if it has any, and warns
+// when there's no "-i" input flag, which is almost always a mistake.
+func validatePreset(p Preset) []validationIssue {
+	var issues []validationIssue
+
+	if p.RawArgs == "" {
+		return issues
+	}
+
+	tokens, err := tokenizePresetArgs(p.RawArgs)
+	if err != nil {
+		return append(issues, validationIssue{"error", fmt.Sprintf("%s: %v", p.Name, err)})
+	}
+
+	hasInput := false
+	for _, t := range tokens {
+		if t == "-i" {
+			hasInput = true
+			break
+		}
+	}
+	if !hasInput {
+		issues = append(issues, validationIssue{"warning", fmt.Sprintf("%s: no -i input flag found", p.Name)})
+	}
+
+	return issues
+}